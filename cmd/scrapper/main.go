@@ -6,22 +6,60 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/josephmowjew/form-field-extractor/pkg/scrapper"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "fill" {
+		if err := runFill(os.Args[2:]); err != nil {
+			log.Fatalf("Failed to fill PDF form: %v", err)
+		}
+		return
+	}
+	runExtract()
+}
+
+func runExtract() {
 	url := flag.String("url", "", "URL of the form to extract (PDF or HTML)")
 	timeout := flag.Duration("timeout", 30*time.Second, "Timeout for operations")
 	maxAttempts := flag.Int("max-attempts", 3, "Maximum number of retry attempts")
+	format := flag.String("format", "json", "Output format: "+strings.Join(formatNames(), ", "))
+	output := flag.String("output", "", "File to write output to (default: stdout)")
+	serve := flag.String("serve", "", "Listen on this address (e.g. :8080) and run as an HTTP service instead of extracting -url once")
 	flag.Parse()
 
+	if *serve != "" {
+		s := scrapper.New(
+			scrapper.WithTimeout(*timeout),
+			scrapper.WithMaxAttempts(*maxAttempts),
+		)
+		srv, err := scrapper.NewServer(s)
+		if err != nil {
+			log.Fatalf("Failed to start server: %v", err)
+		}
+		defer srv.Close()
+
+		log.Printf("Listening on %s", *serve)
+		if err := srv.ListenAndServe(*serve); err != nil {
+			log.Fatalf("Server failed: %v", err)
+		}
+		return
+	}
+
 	if *url == "" {
 		flag.Usage()
 		os.Exit(1)
 	}
 
+	formatter, ok := scrapper.Formatters[*format]
+	if !ok {
+		log.Fatalf("Unknown format %q, expected one of: %s", *format, strings.Join(formatNames(), ", "))
+	}
+
 	// Create a new scrapper instance with options
 	s := scrapper.New(
 		scrapper.WithTimeout(*timeout),
@@ -34,11 +72,76 @@ func main() {
 		log.Fatalf("Failed to extract fields: %v", err)
 	}
 
-	// Convert to JSON and print
-	jsonData, err := json.MarshalIndent(fields, "", "  ")
+	w := os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			log.Fatalf("Failed to create output file: %v", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err := formatter.Format(fields, w); err != nil {
+		log.Fatalf("Failed to format fields: %v", err)
+	}
+}
+
+// runFill implements the "fill" subcommand: it reads the JSON fields emitted
+// by "-format json" (with "value" populated by the caller) and fills them
+// into a PDF form.
+func runFill(args []string) error {
+	fs := flag.NewFlagSet("fill", flag.ExitOnError)
+	pdfPath := fs.String("pdf", "", "Source PDF form to fill")
+	fieldsPath := fs.String("fields", "", "JSON file of fields to fill, as emitted by -format json with value populated")
+	output := fs.String("output", "", "File to write the filled PDF to (default: stdout)")
+	fs.Parse(args)
+
+	if *pdfPath == "" || *fieldsPath == "" {
+		fs.Usage()
+		return fmt.Errorf("-pdf and -fields are both required")
+	}
+
+	pdfFile, err := os.Open(*pdfPath)
 	if err != nil {
-		log.Fatalf("Failed to marshal JSON: %v", err)
+		return fmt.Errorf("failed to open PDF: %w", err)
+	}
+	defer pdfFile.Close()
+
+	fieldsData, err := os.ReadFile(*fieldsPath)
+	if err != nil {
+		return fmt.Errorf("failed to read fields file: %w", err)
+	}
+
+	var fields []scrapper.FormField
+	if err := json.Unmarshal(fieldsData, &fields); err != nil {
+		return fmt.Errorf("failed to parse fields JSON: %w", err)
 	}
 
-	fmt.Println(string(jsonData))
+	values := make(map[string]string, len(fields))
+	for _, f := range fields {
+		values[f.Name] = f.Value
+	}
+
+	w := os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	return scrapper.FillPDFForm(pdfFile, w, values)
+}
+
+// formatNames returns the sorted set of -format flag values.
+func formatNames() []string {
+	names := make([]string, 0, len(scrapper.Formatters))
+	for name := range scrapper.Formatters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
@@ -0,0 +1,358 @@
+package scrapper
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/go-rod/rod"
+)
+
+// Result is what ExtractBatch and Crawl stream back for each URL they
+// process.
+type Result struct {
+	URL    string
+	Fields []FormField
+	Err    error
+}
+
+// ExtractBatch extracts form fields from every URL received on urls,
+// streaming one Result per URL back on the returned channel. Up to
+// Config.Concurrency URLs are processed at once, sharing a single
+// rod.Browser (one Page per in-flight URL) so that PDF/HTML mixes and large
+// batches don't pay Chromium's cold-start cost per page. The returned
+// channel is closed once urls is drained (or ctx is done) and every worker
+// has finished.
+func (s *Scrapper) ExtractBatch(ctx context.Context, urls <-chan string) (<-chan Result, error) {
+	concurrency := s.config.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	browser, err := newBrowser(s.config)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(chan Result)
+
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			s.batchWorker(ctx, browser, urls, results)
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		browser.MustClose()
+		close(results)
+	}()
+
+	return results, nil
+}
+
+func (s *Scrapper) batchWorker(ctx context.Context, browser *rod.Browser, urls <-chan string, results chan<- Result) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case u, ok := <-urls:
+			if !ok {
+				return
+			}
+			fields, err := s.extractOnBrowser(browser, u)
+			select {
+			case results <- Result{URL: u, Fields: fields, Err: err}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// extractOnBrowser mirrors newFormExtractor, but hands the HTML extractor an
+// already-running browser instead of letting it launch its own.
+func (s *Scrapper) extractOnBrowser(browser *rod.Browser, rawURL string) ([]FormField, error) {
+	if strings.HasSuffix(strings.ToLower(rawURL), ".pdf") {
+		extractor, err := NewPDFFormExtractor(rawURL, s.config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create form extractor: %w", err)
+		}
+		defer extractor.Close()
+		return extractExtractor(extractor)
+	}
+
+	rules, err := s.rulesFor(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	extractor, err := newHTMLFormExtractorOnBrowser(browser, false, rawURL, s.config, rules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create form extractor: %w", err)
+	}
+	defer extractor.Close()
+	return extractExtractor(extractor)
+}
+
+func extractExtractor(extractor FormExtractor) ([]FormField, error) {
+	fields, err := extractor.Extract()
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract fields: %w", err)
+	}
+	return fields, nil
+}
+
+// CrawlOptions configures Scrapper.Crawl.
+type CrawlOptions struct {
+	// MaxDepth is how many link-hops to follow from the seed URL. 0 means
+	// only the seed URL itself is extracted.
+	MaxDepth int
+	// MaxPages caps the total number of pages visited, 0 means unlimited.
+	MaxPages int
+	// QueueFile, if set, is the path to the on-disk append-only visit log
+	// (see newVisitQueue) used to dedupe across runs, not just within one.
+	QueueFile string
+}
+
+type crawlTask struct {
+	url   string
+	depth int
+}
+
+// crawlQueue is a dynamically growing, mutex-protected work queue: unlike a
+// buffered channel, pushing never blocks, which matters here because a
+// single page can enqueue arbitrarily many links. A worker pool drains it
+// until the queue is empty and no worker is still processing a task (so no
+// more tasks can be added).
+type crawlQueue struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	tasks  []crawlTask
+	active int
+	closed bool
+}
+
+func newCrawlQueue() *crawlQueue {
+	q := &crawlQueue{}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+func (q *crawlQueue) push(t crawlTask) {
+	q.mu.Lock()
+	q.tasks = append(q.tasks, t)
+	q.mu.Unlock()
+	q.cond.Broadcast()
+}
+
+// pop blocks until a task is available, the queue has drained (no pending
+// tasks and no worker still active, meaning nothing more can be pushed), or
+// the queue was closed. ok is false in the latter two cases.
+func (q *crawlQueue) pop() (t crawlTask, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for len(q.tasks) == 0 && q.active > 0 && !q.closed {
+		q.cond.Wait()
+	}
+
+	if q.closed || len(q.tasks) == 0 {
+		return crawlTask{}, false
+	}
+
+	t = q.tasks[0]
+	q.tasks = q.tasks[1:]
+	q.active++
+	return t, true
+}
+
+// done marks the most recently popped task as finished, waking any worker
+// blocked in pop waiting to learn the queue has drained.
+func (q *crawlQueue) done() {
+	q.mu.Lock()
+	q.active--
+	q.mu.Unlock()
+	q.cond.Broadcast()
+}
+
+func (q *crawlQueue) close() {
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+	q.cond.Broadcast()
+}
+
+// Crawl starts at seedURL and follows in-page links up to opts.MaxDepth,
+// extracting form fields from every page (or PDF) it visits and streaming
+// one Result per page on the returned channel. It shares Config.AllowedHosts
+// and Config.Concurrency with ExtractBatch, honors robots.txt, and
+// deduplicates visited URLs via a bounded visitQueue.
+func (s *Scrapper) Crawl(ctx context.Context, seedURL string, opts CrawlOptions) (<-chan Result, error) {
+	visited, err := newVisitQueue(opts.QueueFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open visit queue: %w", err)
+	}
+
+	concurrency := s.config.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	browser, err := newBrowser(s.config)
+	if err != nil {
+		visited.Close()
+		return nil, err
+	}
+
+	robots := newRobotsCache(nil)
+	queue := newCrawlQueue()
+	queue.push(crawlTask{url: seedURL, depth: 0})
+
+	results := make(chan Result)
+	state := &crawlState{visited: visited, robots: robots, opts: opts}
+
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			s.crawlWorker(ctx, browser, queue, state, results)
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		browser.MustClose()
+		visited.Close()
+		close(results)
+	}()
+
+	// ctx cancellation must also be able to unblock workers parked in
+	// queue.pop() waiting on the condition variable.
+	go func() {
+		<-ctx.Done()
+		queue.close()
+	}()
+
+	return results, nil
+}
+
+// crawlState holds the bookkeeping shared by every crawlWorker goroutine.
+type crawlState struct {
+	visited *visitQueue
+	robots  *robotsCache
+	opts    CrawlOptions
+
+	mu           sync.Mutex
+	visitedCount int
+}
+
+func (s *Scrapper) crawlWorker(ctx context.Context, browser *rod.Browser, queue *crawlQueue, state *crawlState, results chan<- Result) {
+	for {
+		task, ok := queue.pop()
+		if !ok {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			queue.done()
+			return
+		default:
+		}
+
+		s.crawlOne(ctx, browser, queue, state, task, results)
+		queue.done()
+	}
+}
+
+func (s *Scrapper) crawlOne(ctx context.Context, browser *rod.Browser, queue *crawlQueue, state *crawlState, task crawlTask, results chan<- Result) {
+	if state.visited.Seen(task.url) {
+		return
+	}
+	if !s.hostAllowed(task.url) {
+		return
+	}
+	if allowed, err := state.robots.Allowed(task.url); err == nil && !allowed {
+		return
+	}
+
+	state.mu.Lock()
+	if state.opts.MaxPages > 0 && state.visitedCount >= state.opts.MaxPages {
+		state.mu.Unlock()
+		return
+	}
+	state.visitedCount++
+	state.mu.Unlock()
+
+	if err := state.visited.MarkVisited(task.url); err != nil {
+		s.sendResult(ctx, results, Result{URL: task.url, Err: err})
+		return
+	}
+
+	if strings.HasSuffix(strings.ToLower(task.url), ".pdf") {
+		fields, err := s.extractOnBrowser(browser, task.url)
+		s.sendResult(ctx, results, Result{URL: task.url, Fields: fields, Err: err})
+		return
+	}
+
+	rules, err := s.rulesFor(task.url)
+	if err != nil {
+		s.sendResult(ctx, results, Result{URL: task.url, Err: err})
+		return
+	}
+
+	extractor, err := newHTMLFormExtractorOnBrowser(browser, false, task.url, s.config, rules)
+	if err != nil {
+		s.sendResult(ctx, results, Result{URL: task.url, Err: fmt.Errorf("failed to create form extractor: %w", err)})
+		return
+	}
+	defer extractor.Close()
+
+	fields, err := extractor.Extract()
+	s.sendResult(ctx, results, Result{URL: task.url, Fields: fields, Err: err})
+	if err != nil || task.depth >= state.opts.MaxDepth {
+		return
+	}
+
+	links, err := extractor.Links()
+	if err != nil {
+		return
+	}
+	for _, link := range links {
+		queue.push(crawlTask{url: link, depth: task.depth + 1})
+	}
+}
+
+func (s *Scrapper) sendResult(ctx context.Context, results chan<- Result, r Result) {
+	select {
+	case results <- r:
+	case <-ctx.Done():
+	}
+}
+
+// hostAllowed reports whether rawURL's host is permitted by
+// Config.AllowedHosts. An empty AllowedHosts allows every host.
+func (s *Scrapper) hostAllowed(rawURL string) bool {
+	if len(s.config.AllowedHosts) == 0 {
+		return true
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	for _, host := range s.config.AllowedHosts {
+		if strings.EqualFold(u.Host, host) {
+			return true
+		}
+	}
+	return false
+}
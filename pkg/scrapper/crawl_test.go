@@ -0,0 +1,80 @@
+package scrapper
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCrawlQueueDrainsAndClosesPop(t *testing.T) {
+	q := newCrawlQueue()
+	q.push(crawlTask{url: "https://example.com/a", depth: 0})
+
+	task, ok := q.pop()
+	if !ok || task.url != "https://example.com/a" {
+		t.Fatalf("expected to pop the seed task, got %+v ok=%v", task, ok)
+	}
+
+	// No tasks left and this one hasn't finished yet, so a second worker
+	// should block rather than see the queue as drained.
+	popped := make(chan bool, 1)
+	go func() {
+		_, ok := q.pop()
+		popped <- ok
+	}()
+
+	select {
+	case <-popped:
+		t.Fatal("pop returned before the queue actually drained")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	q.done()
+
+	select {
+	case ok := <-popped:
+		if ok {
+			t.Fatal("expected pop to report the queue drained")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("pop did not unblock after the last task finished")
+	}
+}
+
+func TestCrawlQueuePushWakesWaitingWorker(t *testing.T) {
+	q := newCrawlQueue()
+	q.push(crawlTask{url: "seed", depth: 0})
+	_, _ = q.pop() // active=1, queue now empty
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	var got crawlTask
+	var ok bool
+	go func() {
+		defer wg.Done()
+		got, ok = q.pop()
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	q.push(crawlTask{url: "child", depth: 1})
+	wg.Wait()
+
+	if !ok || got.url != "child" {
+		t.Fatalf("expected the waiting pop to receive the pushed task, got %+v ok=%v", got, ok)
+	}
+}
+
+func TestHostAllowed(t *testing.T) {
+	s := New()
+	if !s.hostAllowed("https://example.com/a") {
+		t.Fatal("expected every host to be allowed when AllowedHosts is empty")
+	}
+
+	s = New(WithAllowedHosts([]string{"example.com"}))
+	if !s.hostAllowed("https://example.com/a") {
+		t.Fatal("expected example.com to be allowed")
+	}
+	if s.hostAllowed("https://evil.example/a") {
+		t.Fatal("expected a host outside AllowedHosts to be rejected")
+	}
+}
@@ -3,6 +3,7 @@ package scrapper
 import (
 	"fmt"
 	"strings"
+	"sync"
 )
 
 // FormExtractor interface for different form extraction implementations
@@ -14,6 +15,10 @@ type FormExtractor interface {
 // Scrapper is the main library interface
 type Scrapper struct {
 	config *Config
+
+	rulesOnce sync.Once
+	rulesErr  error
+	rules     []Rule
 }
 
 // New creates a new Scrapper instance with the provided options
@@ -46,7 +51,34 @@ func (s *Scrapper) ExtractFields(url string) ([]FormField, error) {
 // newFormExtractor creates the appropriate form extractor based on the URL
 func (s *Scrapper) newFormExtractor(url string) (FormExtractor, error) {
 	if strings.HasSuffix(strings.ToLower(url), ".pdf") {
-		return NewPDFFormExtractor(url)
+		return NewPDFFormExtractor(url, s.config)
+	}
+
+	rules, err := s.rulesFor(url)
+	if err != nil {
+		return nil, err
+	}
+	return NewHTMLFormExtractor(url, s.config, rules)
+}
+
+// rulesFor returns the rules (if any) that apply to url, loading them from
+// s.config.RulesDir on first use. The load is guarded by a sync.Once since a
+// Scrapper is shared across concurrent workers by ExtractBatch, Crawl and
+// Server.
+func (s *Scrapper) rulesFor(url string) ([]Rule, error) {
+	if s.config.RulesDir == "" {
+		return nil, nil
 	}
-	return NewHTMLFormExtractor(url, s.config.Timeout)
+
+	s.rulesOnce.Do(func() {
+		s.rules, s.rulesErr = LoadRules(s.config.RulesDir)
+		if s.rulesErr != nil {
+			s.rulesErr = fmt.Errorf("failed to load rules from %s: %w", s.config.RulesDir, s.rulesErr)
+		}
+	})
+	if s.rulesErr != nil {
+		return nil, s.rulesErr
+	}
+
+	return matchingRules(s.rules, url), nil
 }
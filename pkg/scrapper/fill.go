@@ -0,0 +1,84 @@
+package scrapper
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/form"
+)
+
+// FillPDFForm populates src's AcroForm fields from values (keyed by field
+// name, as produced by PDFFormExtractor.Extract) and writes the completed
+// PDF to dst. It round-trips through pdfcpu's own form JSON: export src's
+// form to discover each field's type and options, overwrite the values, and
+// feed the result back into pdfcpu's FillForm.
+//
+// Checkbox values are "on" or "off"; radio button and combo box values are
+// the chosen option's name; list box values are a comma-separated set of
+// option names for fields that accept multiple selections.
+func FillPDFForm(src io.Reader, dst io.Writer, values map[string]string) error {
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return fmt.Errorf("failed to read PDF: %w", err)
+	}
+
+	formGroup, err := api.ExportForm(bytes.NewReader(data), "source", nil)
+	if err != nil {
+		return fmt.Errorf("failed to export PDF form: %w", err)
+	}
+	if len(formGroup.Forms) == 0 {
+		return fmt.Errorf("PDF has no form fields to fill")
+	}
+
+	applyFillValues(&formGroup.Forms[0], values)
+
+	filled, err := json.Marshal(formGroup)
+	if err != nil {
+		return fmt.Errorf("failed to encode fill data: %w", err)
+	}
+
+	if err := api.FillForm(bytes.NewReader(data), bytes.NewReader(filled), dst, nil); err != nil {
+		return fmt.Errorf("failed to fill PDF form: %w", err)
+	}
+	return nil
+}
+
+// applyFillValues overwrites f's field values in place from values, keyed by
+// field name. Fields with no matching entry in values keep whatever value
+// ExportForm reported (typically the PDF's existing value or default).
+func applyFillValues(f *form.Form, values map[string]string) {
+	for _, tf := range f.TextFields {
+		if v, ok := values[tf.Name]; ok {
+			tf.Value = v
+		}
+	}
+	for _, df := range f.DateFields {
+		if v, ok := values[df.Name]; ok {
+			df.Value = v
+		}
+	}
+	for _, cb := range f.CheckBoxes {
+		if v, ok := values[cb.Name]; ok {
+			cb.Value = v == "on"
+		}
+	}
+	for _, rbg := range f.RadioButtonGroups {
+		if v, ok := values[rbg.Name]; ok {
+			rbg.Value = v
+		}
+	}
+	for _, cmb := range f.ComboBoxes {
+		if v, ok := values[cmb.Name]; ok {
+			cmb.Value = v
+		}
+	}
+	for _, lb := range f.ListBoxes {
+		if v, ok := values[lb.Name]; ok {
+			lb.Values = strings.Split(v, ",")
+		}
+	}
+}
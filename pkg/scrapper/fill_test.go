@@ -0,0 +1,67 @@
+package scrapper
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestFillPDFFormRoundTrips(t *testing.T) {
+	src, err := os.Open("testdata/pdf/english.pdf")
+	if err != nil {
+		t.Fatalf("failed to open test PDF: %v", err)
+	}
+	defer src.Close()
+
+	values := map[string]string{
+		"firstName1": "Ada",
+		"cb11":       "on",
+		"gender1":    "female",
+		"city12":     "London",
+		"city11":     "Vienna",
+	}
+
+	var dst bytes.Buffer
+	if err := FillPDFForm(src, &dst, values); err != nil {
+		t.Fatalf("FillPDFForm returned error: %v", err)
+	}
+
+	p := &PDFFormExtractor{file: mustReopen(t, dst.Bytes())}
+	fields, err := p.Extract()
+	if err != nil {
+		t.Fatalf("failed to extract fields back out of the filled PDF: %v", err)
+	}
+
+	cases := map[string]string{
+		"firstName1": "Ada",
+		"cb11":       "Yes",
+		"gender1":    "female",
+		"city12":     "London",
+		"city11":     "Vienna",
+	}
+	for name, want := range cases {
+		got := fieldByName(t, fields, name)
+		if got.Value != want {
+			t.Errorf("field %q = %q, want %q", name, got.Value, want)
+		}
+	}
+}
+
+// mustReopen writes data to a temp file and opens it, since pdfcpu's
+// FormFields needs an io.ReadSeeker rather than the io.Writer FillPDFForm
+// produced.
+func mustReopen(t *testing.T, data []byte) *os.File {
+	t.Helper()
+	f, err := os.CreateTemp(t.TempDir(), "filled-*.pdf")
+	if err != nil {
+		t.Fatalf("failed to create temp file: %v", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+	if _, err := f.Seek(0, 0); err != nil {
+		t.Fatalf("failed to seek temp file: %v", err)
+	}
+	t.Cleanup(func() { f.Close() })
+	return f
+}
@@ -0,0 +1,282 @@
+package scrapper
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Formatter renders extracted form fields to w in some output format.
+type Formatter interface {
+	Format(fields []FormField, w io.Writer) error
+}
+
+// Formatters maps the -format flag values cmd/scrapper/main.go accepts to
+// their Formatter implementation.
+var Formatters = map[string]Formatter{
+	"json":        JSONFormatter{},
+	"csv":         CSVFormatter{},
+	"yaml":        YAMLFormatter{},
+	"json-schema": JSONSchemaFormatter{},
+	"go-struct":   GoStructFormatter{},
+	"html":        HTMLPreviewFormatter{},
+}
+
+// JSONFormatter renders fields as indented JSON, matching the tool's
+// historical default output.
+type JSONFormatter struct{}
+
+// Format implements Formatter.
+func (JSONFormatter) Format(fields []FormField, w io.Writer) error {
+	data, err := json.MarshalIndent(fields, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// CSVFormatter renders fields as a CSV with one row per field.
+type CSVFormatter struct{}
+
+// Format implements Formatter.
+func (CSVFormatter) Format(fields []FormField, w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{"name", "type", "label", "required", "value"}); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, f := range fields {
+		row := []string{
+			neutralizeCSVFormula(f.Name),
+			neutralizeCSVFormula(f.Type),
+			neutralizeCSVFormula(f.Label),
+			fmt.Sprintf("%t", f.Required),
+			neutralizeCSVFormula(f.Value),
+		}
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// neutralizeCSVFormula prefixes s with a "'" when it starts with a
+// character ("=", "+", "-", "@", or a tab/carriage return that some
+// spreadsheet software trims before re-checking for a formula trigger) that
+// spreadsheet software treats as the start of a formula, so a scraped value
+// like =HYPERLINK(...) can't execute when the CSV is opened in Excel/Sheets.
+func neutralizeCSVFormula(s string) string {
+	if s == "" {
+		return s
+	}
+	switch s[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + s
+	default:
+		return s
+	}
+}
+
+// YAMLFormatter renders fields as YAML.
+type YAMLFormatter struct{}
+
+// Format implements Formatter.
+func (YAMLFormatter) Format(fields []FormField, w io.Writer) error {
+	data, err := yaml.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// jsonSchemaProperty is a single field's entry in a JSON Schema's
+// "properties" map.
+type jsonSchemaProperty struct {
+	Type    string `json:"type"`
+	Format  string `json:"format,omitempty"`
+	Title   string `json:"title,omitempty"`
+	Default string `json:"default,omitempty"`
+}
+
+// jsonSchema is a minimal draft 2020-12 object schema: enough to describe
+// a form's fields and which are required, for downstream validators.
+type jsonSchema struct {
+	Schema     string                        `json:"$schema"`
+	Type       string                        `json:"type"`
+	Properties map[string]jsonSchemaProperty `json:"properties"`
+	Required   []string                      `json:"required,omitempty"`
+}
+
+// JSONSchemaFormatter renders fields as a draft 2020-12 JSON Schema, with
+// "required" filled in and "type"/"format" mapped from each field's HTML
+// input type.
+type JSONSchemaFormatter struct{}
+
+// Format implements Formatter.
+func (JSONSchemaFormatter) Format(fields []FormField, w io.Writer) error {
+	schema := jsonSchema{
+		Schema:     "https://json-schema.org/draft/2020-12/schema",
+		Type:       "object",
+		Properties: make(map[string]jsonSchemaProperty, len(fields)),
+	}
+
+	for _, f := range fields {
+		typ, format := jsonSchemaType(f.Type)
+		schema.Properties[f.Name] = jsonSchemaProperty{
+			Type:    typ,
+			Format:  format,
+			Title:   f.Label,
+			Default: f.Default,
+		}
+		if f.Required {
+			schema.Required = append(schema.Required, f.Name)
+		}
+	}
+	sort.Strings(schema.Required)
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON Schema: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// jsonSchemaType maps an HTML input type to a JSON Schema type/format pair,
+// e.g. "email" -> ("string", "email"), "number" -> ("number", "").
+func jsonSchemaType(htmlType string) (typ, format string) {
+	switch htmlType {
+	case "email":
+		return "string", "email"
+	case "date":
+		return "string", "date"
+	case "datetime-local":
+		return "string", "date-time"
+	case "time":
+		return "string", "time"
+	case "url":
+		return "string", "uri"
+	case "number", "range":
+		return "number", ""
+	case "checkbox":
+		return "boolean", ""
+	default:
+		return "string", ""
+	}
+}
+
+// GoStructFormatter renders fields as a Go struct definition, one field per
+// form field, tagged with its original name.
+type GoStructFormatter struct{}
+
+// Format implements Formatter.
+func (GoStructFormatter) Format(fields []FormField, w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("type ExtractedForm struct {\n")
+	for _, f := range fields {
+		b.WriteString(fmt.Sprintf("\t%s %s `json:%q`\n", goFieldName(f.Name), goFieldType(f.Type), f.Name))
+	}
+	b.WriteString("}\n")
+
+	_, err := w.Write([]byte(b.String()))
+	return err
+}
+
+// goFieldName converts a form field name (e.g. "first_name" or
+// "email-address") into an exported Go identifier (e.g. "FirstName").
+func goFieldName(name string) string {
+	var b strings.Builder
+	upperNext := true
+	for _, r := range name {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if upperNext {
+				b.WriteRune(unicode.ToUpper(r))
+				upperNext = false
+			} else {
+				b.WriteRune(r)
+			}
+		default:
+			upperNext = true
+		}
+	}
+	if b.Len() == 0 {
+		return "Field"
+	}
+
+	result := b.String()
+	if unicode.IsDigit(rune(result[0])) {
+		result = "Field" + result
+	}
+	return result
+}
+
+// goFieldType maps an HTML input type to a Go field type.
+func goFieldType(htmlType string) string {
+	switch htmlType {
+	case "number", "range":
+		return "float64"
+	case "checkbox":
+		return "bool"
+	default:
+		return "string"
+	}
+}
+
+// HTMLPreviewFormatter renders a minimal re-creation of the form so a user
+// can visually verify the extraction.
+type HTMLPreviewFormatter struct{}
+
+// Format implements Formatter.
+func (HTMLPreviewFormatter) Format(fields []FormField, w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html>\n<body>\n<form>\n")
+
+	for _, f := range fields {
+		b.WriteString("  <label>\n")
+		b.WriteString(fmt.Sprintf("    %s%s\n", html.EscapeString(f.Label), requiredMarker(f.Required)))
+		b.WriteString(fmt.Sprintf(
+			"    <input type=\"%s\" name=\"%s\" value=\"%s\"%s>\n",
+			html.EscapeString(htmlOrDefault(f.Type)), html.EscapeString(f.Name), html.EscapeString(f.Value), requiredAttr(f.Required),
+		))
+		b.WriteString("  </label>\n")
+	}
+
+	b.WriteString("</form>\n</body>\n</html>\n")
+
+	_, err := w.Write([]byte(b.String()))
+	return err
+}
+
+func requiredMarker(required bool) string {
+	if required {
+		return " *"
+	}
+	return ""
+}
+
+func requiredAttr(required bool) string {
+	if required {
+		return " required"
+	}
+	return ""
+}
+
+func htmlOrDefault(htmlType string) string {
+	if htmlType == "" {
+		return "text"
+	}
+	return htmlType
+}
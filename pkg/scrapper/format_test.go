@@ -0,0 +1,173 @@
+package scrapper
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func sampleFields() []FormField {
+	return []FormField{
+		{Name: "email", Type: "email", Label: "Email", Required: true, Default: "default@example.com"},
+		{Name: "age", Type: "number", Label: "Age"},
+	}
+}
+
+func TestJSONFormatter(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (JSONFormatter{}).Format(sampleFields(), &buf); err != nil {
+		t.Fatalf("Format returned error: %v", err)
+	}
+
+	var got []FormField
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 fields, got %d", len(got))
+	}
+}
+
+func TestCSVFormatter(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (CSVFormatter{}).Format(sampleFields(), &buf); err != nil {
+		t.Fatalf("Format returned error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected a header row and 2 data rows, got %d lines: %q", len(lines), buf.String())
+	}
+	if lines[0] != "name,type,label,required,value" {
+		t.Errorf("unexpected CSV header: %q", lines[0])
+	}
+}
+
+func TestCSVFormatterNeutralizesFormulas(t *testing.T) {
+	fields := []FormField{
+		{Name: "name", Type: "text", Label: "Label", Value: `=HYPERLINK("http://evil","x")`},
+	}
+
+	var buf bytes.Buffer
+	if err := (CSVFormatter{}).Format(fields, &buf); err != nil {
+		t.Fatalf("Format returned error: %v", err)
+	}
+
+	if strings.Contains(buf.String(), `,=HYPERLINK`) {
+		t.Errorf("expected a leading '=' to be neutralized against CSV formula injection, got: %s", buf.String())
+	}
+	if !strings.Contains(buf.String(), `'=HYPERLINK`) {
+		t.Errorf("expected the value to be prefixed with a ' to neutralize it, got: %s", buf.String())
+	}
+}
+
+func TestNeutralizeCSVFormula(t *testing.T) {
+	cases := map[string]string{
+		"":                  "",
+		"plain":             "plain",
+		"=HYPERLINK(...)":   "'=HYPERLINK(...)",
+		"+1+1":              "'+1+1",
+		"-1":                "'-1",
+		"@SUM(A1)":          "'@SUM(A1)",
+		"\t=HYPERLINK(...)": "'\t=HYPERLINK(...)",
+		"\r=HYPERLINK(...)": "'\r=HYPERLINK(...)",
+	}
+	for in, want := range cases {
+		if got := neutralizeCSVFormula(in); got != want {
+			t.Errorf("neutralizeCSVFormula(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestJSONSchemaFormatter(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (JSONSchemaFormatter{}).Format(sampleFields(), &buf); err != nil {
+		t.Fatalf("Format returned error: %v", err)
+	}
+
+	var schema jsonSchema
+	if err := json.Unmarshal(buf.Bytes(), &schema); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+
+	if schema.Properties["email"].Format != "email" {
+		t.Errorf("expected email field to map to format:email, got %+v", schema.Properties["email"])
+	}
+	if schema.Properties["email"].Default != "default@example.com" {
+		t.Errorf("expected the schema default to come from FormField.Default, got %+v", schema.Properties["email"])
+	}
+	if schema.Properties["age"].Type != "number" {
+		t.Errorf("expected age field to map to type:number, got %+v", schema.Properties["age"])
+	}
+	if len(schema.Required) != 1 || schema.Required[0] != "email" {
+		t.Errorf("expected only email to be required, got %v", schema.Required)
+	}
+}
+
+func TestGoStructFormatter(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (GoStructFormatter{}).Format(sampleFields(), &buf); err != nil {
+		t.Fatalf("Format returned error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "type ExtractedForm struct {") {
+		t.Errorf("expected a struct definition, got: %s", out)
+	}
+	if !strings.Contains(out, "Email string `json:\"email\"`") {
+		t.Errorf("expected an Email string field, got: %s", out)
+	}
+	if !strings.Contains(out, "Age float64 `json:\"age\"`") {
+		t.Errorf("expected an Age float64 field, got: %s", out)
+	}
+}
+
+func TestHTMLPreviewFormatter(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (HTMLPreviewFormatter{}).Format(sampleFields(), &buf); err != nil {
+		t.Fatalf("Format returned error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, `<input type="email" name="email" value=""`) {
+		t.Errorf("expected an email input, got: %s", out)
+	}
+	if !strings.Contains(out, "required>") {
+		t.Errorf("expected the required field to render a required attribute, got: %s", out)
+	}
+}
+
+func TestHTMLPreviewFormatterEscapesAttributes(t *testing.T) {
+	fields := []FormField{
+		{Name: `x" onmouseover="alert(1)`, Type: "text", Value: `" ><script>alert(1)</script><x y="`},
+	}
+
+	var buf bytes.Buffer
+	if err := (HTMLPreviewFormatter{}).Format(fields, &buf); err != nil {
+		t.Fatalf("Format returned error: %v", err)
+	}
+
+	out := buf.String()
+	if strings.Contains(out, "<script>") {
+		t.Errorf("expected field values to be HTML-escaped, got a live <script> tag: %s", out)
+	}
+	if strings.Contains(out, `onmouseover="alert`) {
+		t.Errorf("expected field names to be HTML-escaped, got an unescaped attribute breakout: %s", out)
+	}
+}
+
+func TestGoFieldName(t *testing.T) {
+	cases := map[string]string{
+		"first_name":    "FirstName",
+		"email-address": "EmailAddress",
+		"zip":           "Zip",
+		"":              "Field",
+		"2fa_code":      "Field2faCode",
+	}
+	for in, want := range cases {
+		if got := goFieldName(in); got != want {
+			t.Errorf("goFieldName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -2,9 +2,14 @@ package scrapper
 
 import (
 	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/launcher"
+	"github.com/go-rod/rod/lib/proto"
 )
 
 // HTMLFormExtractor implements FormExtractor for HTML forms
@@ -13,38 +18,160 @@ type HTMLFormExtractor struct {
 	page    *rod.Page
 	url     string
 	timeout time.Duration
-}
+	rules   []Rule
 
-// NewHTMLFormExtractor creates a new HTML form extractor
-func NewHTMLFormExtractor(url string, timeout time.Duration) (*HTMLFormExtractor, error) {
-	launcher := rod.New()
-	browser := launcher.MustConnect()
+	// ownsBrowser is true when this extractor launched browser itself and
+	// Close should shut it down. Crawl/batch callers share one browser
+	// across many extractors (see newHTMLFormExtractorOnBrowser) and close
+	// it themselves once every worker is done.
+	ownsBrowser bool
+}
 
-	page := browser.MustPage()
+// NewHTMLFormExtractor creates a new HTML form extractor backed by its own,
+// dedicated browser. rules should already be filtered down to the ones that
+// apply to rawURL (see Scrapper.rulesFor); Extract merges the fields they
+// contribute with the default input/select/textarea scan, de-duplicated by
+// name.
+func NewHTMLFormExtractor(rawURL string, config *Config, rules []Rule) (*HTMLFormExtractor, error) {
+	browser, err := newBrowser(config)
+	if err != nil {
+		return nil, err
+	}
 
-	err := page.Timeout(timeout).Navigate(url)
+	h, err := newHTMLFormExtractorOnBrowser(browser, true, rawURL, config, rules)
 	if err != nil {
 		browser.MustClose()
-		return nil, fmt.Errorf("failed to navigate to URL: %w", err)
+		return nil, err
 	}
+	return h, nil
+}
 
-	err = page.Timeout(timeout).WaitLoad()
+// newBrowser launches and connects a browser configured with config's
+// proxy, if any.
+func newBrowser(config *Config) (*rod.Browser, error) {
+	l := launcher.New()
+	if config.HTTPProxy != "" {
+		l = l.Proxy(config.HTTPProxy)
+	}
+
+	browserURL, err := l.Launch()
 	if err != nil {
-		browser.MustClose()
-		return nil, fmt.Errorf("timeout waiting for page to load: %w", err)
+		return nil, fmt.Errorf("failed to launch browser: %w", err)
+	}
+
+	return rod.New().ControlURL(browserURL).MustConnect(), nil
+}
+
+// newHTMLFormExtractorOnBrowser builds an extractor against an existing
+// browser, opening one new Page for it. It is how Scrapper.ExtractBatch and
+// Scrapper.Crawl share a single rod.Browser across many concurrent workers
+// instead of launching Chromium per URL. ownsBrowser controls whether Close
+// also shuts down the browser, or just the page.
+func newHTMLFormExtractorOnBrowser(browser *rod.Browser, ownsBrowser bool, rawURL string, config *Config, rules []Rule) (*HTMLFormExtractor, error) {
+	page, err := browser.Page(proto.TargetCreateTarget{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open page: %w", err)
+	}
+
+	if err := configurePage(browser, page, rawURL, config); err != nil {
+		page.MustClose()
+		return nil, err
+	}
+
+	err = withRetry(config.MaxAttempts, func() error {
+		if err := page.Timeout(config.Timeout).Navigate(rawURL); err != nil {
+			return fmt.Errorf("failed to navigate to URL: %w", err)
+		}
+		if err := page.Timeout(config.Timeout).WaitLoad(); err != nil {
+			return fmt.Errorf("timeout waiting for page to load: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		page.MustClose()
+		return nil, err
 	}
 
 	return &HTMLFormExtractor{
-		browser: browser,
-		page:    page,
-		url:     url,
-		timeout: timeout,
+		browser:     browser,
+		page:        page,
+		url:         rawURL,
+		timeout:     config.Timeout,
+		rules:       rules,
+		ownsBrowser: ownsBrowser,
 	}, nil
 }
 
+// configurePage applies config's user agent, extra headers, cookie jar,
+// basic auth and login script to page before it navigates to rawURL.
+func configurePage(browser *rod.Browser, page *rod.Page, rawURL string, config *Config) error {
+	if config.UserAgent != "" {
+		if err := page.SetUserAgent(&proto.NetworkSetUserAgentOverride{UserAgent: config.UserAgent}); err != nil {
+			return fmt.Errorf("failed to set user agent: %w", err)
+		}
+	}
+
+	if len(config.Headers) > 0 {
+		var dict []string
+		for k, v := range config.Headers {
+			dict = append(dict, k, v)
+		}
+		if _, err := page.SetExtraHeaders(dict); err != nil {
+			return fmt.Errorf("failed to set extra headers: %w", err)
+		}
+	}
+
+	if config.CookieJar != nil {
+		if err := seedCookies(browser, config.CookieJar, rawURL); err != nil {
+			return err
+		}
+	}
+
+	if config.BasicAuthUser != "" {
+		go browser.MustHandleAuth(config.BasicAuthUser, config.BasicAuthPass)()
+	}
+
+	if config.LoginScript != nil {
+		if err := config.LoginScript(page); err != nil {
+			return fmt.Errorf("login script failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// seedCookies copies the cookies jar holds for rawURL into the browser so
+// the authenticated session they represent carries over to navigation.
+func seedCookies(browser *rod.Browser, jar http.CookieJar, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("failed to parse URL for cookie jar: %w", err)
+	}
+
+	cookies := jar.Cookies(u)
+	if len(cookies) == 0 {
+		return nil
+	}
+
+	params := make([]*proto.NetworkCookieParam, len(cookies))
+	for i, c := range cookies {
+		params[i] = &proto.NetworkCookieParam{
+			Name:  c.Name,
+			Value: c.Value,
+			URL:   rawURL,
+		}
+	}
+
+	if err := browser.SetCookies(params); err != nil {
+		return fmt.Errorf("failed to seed cookies: %w", err)
+	}
+	return nil
+}
+
 // Extract implements FormExtractor for HTMLFormExtractor
 func (h *HTMLFormExtractor) Extract() ([]FormField, error) {
 	var formFields []FormField
+	radioIndex := make(map[string]int)
 
 	elements, err := h.page.Timeout(h.timeout).Elements("input, select, textarea")
 	if err != nil {
@@ -52,10 +179,12 @@ func (h *HTMLFormExtractor) Extract() ([]FormField, error) {
 	}
 
 	for _, element := range elements {
-		typeStr := "text" // default type
-		if t, err := element.Attribute("type"); err == nil && t != nil {
-			typeStr = *t
+		tag, _ := h.tagName(element)
+		var typeAttr *string
+		if t, err := element.Attribute("type"); err == nil {
+			typeAttr = t
 		}
+		typeStr := resolveElementType(tag, typeAttr)
 
 		name, err := element.Attribute("name")
 		if err != nil || name == nil {
@@ -93,21 +222,327 @@ func (h *HTMLFormExtractor) Extract() ([]FormField, error) {
 			required = true
 		}
 
-		formFields = append(formFields, FormField{
+		if typeStr == "radio" {
+			value, selected := h.radioOption(element)
+			if idx, ok := radioIndex[*name]; ok {
+				formFields[idx].Options = append(formFields[idx].Options, FieldOption{Value: value, Selected: selected})
+				if selected {
+					formFields[idx].Default = value
+				}
+				continue
+			}
+			field := FormField{
+				Name:     *name,
+				Type:     typeStr,
+				Label:    label,
+				Required: required,
+				Options:  []FieldOption{{Value: value, Selected: selected}},
+				Group:    h.fieldGroup(element),
+			}
+			if selected {
+				field.Default = value
+			}
+			radioIndex[*name] = len(formFields)
+			formFields = append(formFields, field)
+			continue
+		}
+
+		field := FormField{
 			Name:     *name,
 			Type:     typeStr,
 			Label:    label,
 			Required: required,
-		})
+			Group:    h.fieldGroup(element),
+		}
+
+		if typeStr == "select" {
+			field.Options = h.selectOptions(element)
+			for _, opt := range field.Options {
+				if opt.Selected {
+					field.Default = opt.Value
+				}
+			}
+		} else if v, err := element.Attribute("value"); err == nil && v != nil {
+			field.Default = *v
+		}
+
+		if v, err := element.Attribute("multiple"); err == nil && v != nil {
+			field.Multiple = true
+		}
+		if p, err := element.Attribute("pattern"); err == nil && p != nil {
+			field.Pattern = *p
+		}
+		if m, err := element.Attribute("min"); err == nil && m != nil {
+			field.Min = *m
+		}
+		if m, err := element.Attribute("max"); err == nil && m != nil {
+			field.Max = *m
+		}
+		if ml, err := element.Attribute("maxlength"); err == nil && ml != nil {
+			if n, err := strconv.Atoi(*ml); err == nil {
+				field.MaxLength = n
+			}
+		}
+
+		formFields = append(formFields, field)
+	}
+
+	ruleFields, err := h.extractRuleFields()
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract rule fields: %w", err)
+	}
+
+	return mergeFields(formFields, ruleFields), nil
+}
+
+// tagName returns element's lowercased tag name, e.g. "select" or "input".
+// Unlike "type", every element has one, so it's used to tell a <select>
+// apart from an <input> (neither of which carries a "type" attribute that
+// would otherwise distinguish them).
+func (h *HTMLFormExtractor) tagName(element *rod.Element) (string, error) {
+	res, err := element.Eval(`function() { return this.tagName.toLowerCase(); }`)
+	if err != nil {
+		return "", err
 	}
+	return res.Value.String(), nil
+}
 
-	return formFields, nil
+// resolveElementType resolves a form element's effective "type" for Extract.
+// <select> elements never carry a "type" attribute, so they're recognized by
+// tag name instead; everything else falls back to its "type" attribute,
+// defaulting to "text".
+func resolveElementType(tag string, typeAttr *string) string {
+	if tag == "select" {
+		return "select"
+	}
+	if typeAttr != nil {
+		return *typeAttr
+	}
+	return "text"
+}
+
+// radioOption resolves a radio <input>'s value and whether it is checked.
+func (h *HTMLFormExtractor) radioOption(element *rod.Element) (value string, selected bool) {
+	if v, err := element.Attribute("value"); err == nil && v != nil {
+		value = *v
+	}
+	if c, err := element.Attribute("checked"); err == nil && c != nil {
+		selected = true
+	}
+	return value, selected
+}
+
+// selectOptions walks a <select>'s <option> children into FieldOptions.
+func (h *HTMLFormExtractor) selectOptions(element *rod.Element) []FieldOption {
+	optionElements, err := element.Elements("option")
+	if err != nil {
+		return nil
+	}
+
+	var options []FieldOption
+	for _, oe := range optionElements {
+		opt := FieldOption{}
+		if v, err := oe.Attribute("value"); err == nil && v != nil {
+			opt.Value = *v
+		} else if text, err := oe.Text(); err == nil {
+			opt.Value = text
+		}
+		if text, err := oe.Text(); err == nil {
+			opt.Label = text
+		}
+		if s, err := oe.Attribute("selected"); err == nil && s != nil {
+			opt.Selected = true
+		}
+		options = append(options, opt)
+	}
+	return options
+}
+
+// fieldGroup returns the text of the nearest enclosing <fieldset>'s
+// <legend>, or "" when element isn't inside a fieldset with one.
+func (h *HTMLFormExtractor) fieldGroup(element *rod.Element) string {
+	res, err := element.Eval(`function() {
+		const fieldset = this.closest('fieldset');
+		if (!fieldset) return '';
+		const legend = fieldset.querySelector('legend');
+		return legend ? legend.textContent.trim() : '';
+	}`)
+	if err != nil {
+		return ""
+	}
+	return res.Value.String()
+}
+
+// extractRuleFields runs every FieldRule of every matching Rule against the
+// page and returns the fields they resolve.
+func (h *HTMLFormExtractor) extractRuleFields() ([]FormField, error) {
+	var fields []FormField
+
+	for _, rule := range h.rules {
+		for _, fr := range rule.Fields {
+			ruleFields, err := h.extractFieldRule(fr)
+			if err != nil {
+				return nil, fmt.Errorf("rule %q: %w", rule.Name, err)
+			}
+			fields = append(fields, ruleFields...)
+		}
+	}
+
+	return fields, nil
+}
+
+// extractFieldRule resolves every element matched by fr into a FormField.
+func (h *HTMLFormExtractor) extractFieldRule(fr FieldRule) ([]FormField, error) {
+	var elements rod.Elements
+	var err error
+
+	switch {
+	case fr.XPath != "":
+		elements, err = h.page.Timeout(h.timeout).ElementsX(fr.XPath)
+	case fr.Selector != "":
+		elements, err = h.page.Timeout(h.timeout).Elements(fr.Selector)
+	default:
+		return nil, fmt.Errorf("field rule has neither selector nor xpath")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find elements: %w", err)
+	}
+
+	var fields []FormField
+	for _, element := range elements {
+		field, err := h.resolveField(element, fr)
+		if err != nil {
+			return nil, err
+		}
+		if field.Name == "" {
+			continue
+		}
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// resolveField builds a FormField for a single element matched by fr,
+// resolving name/label/type/required either from sub-selectors or, when
+// none is given, from the element's own attributes. JSExpr, if set, is
+// evaluated via rod's Page.Eval to post-process the resolved value.
+func (h *HTMLFormExtractor) resolveField(element *rod.Element, fr FieldRule) (FormField, error) {
+	name, err := h.resolveAttr(element, fr.NameSelector, "name")
+	if err != nil {
+		return FormField{}, err
+	}
+
+	label, err := h.resolveAttr(element, fr.LabelSelector, "")
+	if err != nil {
+		return FormField{}, err
+	}
+	if label == "" {
+		label = name
+	}
+
+	typeStr := fr.Type
+	if typeStr == "" {
+		resolved, err := h.resolveAttr(element, fr.TypeSelector, "type")
+		if err != nil {
+			return FormField{}, err
+		}
+		typeStr = resolved
+	}
+	if typeStr == "" {
+		typeStr = "text"
+	}
+
+	required := false
+	if reqStr, err := h.resolveAttr(element, fr.RequiredSelector, "required"); err != nil {
+		return FormField{}, err
+	} else if reqStr != "" {
+		required = true
+	}
+
+	value := ""
+	if fr.JSExpr != "" {
+		res, err := element.Eval(fmt.Sprintf("function() { const elem = this; return (%s); }", fr.JSExpr))
+		if err != nil {
+			return FormField{}, fmt.Errorf("failed to evaluate jsExpr: %w", err)
+		}
+		value = res.Value.String()
+	}
+
+	return FormField{
+		Name:     name,
+		Type:     typeStr,
+		Label:    label,
+		Required: required,
+		Value:    value,
+	}, nil
+}
+
+// resolveAttr resolves a value either from a descendant matched by
+// subSelector's text, or, when subSelector is empty, from the element's own
+// attr attribute.
+func (h *HTMLFormExtractor) resolveAttr(element *rod.Element, subSelector, attr string) (string, error) {
+	if subSelector != "" {
+		sub, err := element.Element(subSelector)
+		if err != nil {
+			return "", nil //nolint:nilerr // a missing sub-selector just means no value, not a failure
+		}
+		text, err := sub.Text()
+		if err != nil {
+			return "", fmt.Errorf("failed to read sub-selector %q text: %w", subSelector, err)
+		}
+		return text, nil
+	}
+
+	if attr == "" {
+		return "", nil
+	}
+
+	val, err := element.Attribute(attr)
+	if err != nil || val == nil {
+		return "", nil
+	}
+	return *val, nil
 }
 
 // Close implements FormExtractor for HTMLFormExtractor
 func (h *HTMLFormExtractor) Close() error {
-	if h.browser != nil {
+	if h.page != nil {
+		h.page.MustClose()
+	}
+	if h.ownsBrowser && h.browser != nil {
 		h.browser.MustClose()
 	}
 	return nil
 }
+
+// Links returns every in-page anchor href, resolved to an absolute URL
+// against the page's own URL. It is used by Scrapper.Crawl to discover
+// pages to follow; it deliberately ignores hrefs that fail to parse rather
+// than failing the whole extraction.
+func (h *HTMLFormExtractor) Links() ([]string, error) {
+	base, err := url.Parse(h.url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse page URL: %w", err)
+	}
+
+	anchors, err := h.page.Timeout(h.timeout).Elements("a[href]")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find links: %w", err)
+	}
+
+	var links []string
+	for _, a := range anchors {
+		href, err := a.Attribute("href")
+		if err != nil || href == nil || *href == "" {
+			continue
+		}
+		ref, err := url.Parse(*href)
+		if err != nil {
+			continue
+		}
+		links = append(links, base.ResolveReference(ref).String())
+	}
+
+	return links, nil
+}
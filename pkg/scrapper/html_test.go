@@ -0,0 +1,34 @@
+package scrapper
+
+import "testing"
+
+// TestResolveElementType covers Extract's per-element type resolution
+// without needing a live browser (the sandbox this was written in has no
+// Chromium binary available, and h.tagName/element.Attribute both require a
+// connected rod.Page): it's the logic that previously misclassified every
+// <select> as "text" since <select> elements carry no "type" attribute.
+func TestResolveElementType(t *testing.T) {
+	text := "text"
+	email := "email"
+
+	cases := []struct {
+		name     string
+		tag      string
+		typeAttr *string
+		want     string
+	}{
+		{name: "select is recognized by tag, not type attribute", tag: "select", typeAttr: nil, want: "select"},
+		{name: "select with no type attribute still resolves to select", tag: "select", typeAttr: &text, want: "select"},
+		{name: "input falls back to its type attribute", tag: "input", typeAttr: &email, want: "email"},
+		{name: "input with no type attribute defaults to text", tag: "input", typeAttr: nil, want: "text"},
+		{name: "textarea with no type attribute defaults to text", tag: "textarea", typeAttr: nil, want: "text"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := resolveElementType(c.tag, c.typeAttr); got != c.want {
+				t.Errorf("resolveElementType(%q, %v) = %q, want %q", c.tag, c.typeAttr, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package scrapper
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// httpClient builds an *http.Client from config for PDF downloads: a proxy
+// (if set), and the cookie jar so an authenticated session survives across
+// requests.
+func httpClient(config *Config) (*http.Client, error) {
+	client := &http.Client{Jar: config.CookieJar}
+
+	if config.HTTPProxy != "" {
+		proxyURL, err := url.Parse(config.HTTPProxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid HTTP proxy URL: %w", err)
+		}
+		client.Transport = &http.Transport{Proxy: http.ProxyURL(proxyURL)}
+	}
+
+	return client, nil
+}
+
+// applyRequestConfig sets the User-Agent, basic auth and extra headers from
+// config on req.
+func applyRequestConfig(req *http.Request, config *Config) {
+	if config.UserAgent != "" {
+		req.Header.Set("User-Agent", config.UserAgent)
+	}
+	if config.BasicAuthUser != "" {
+		req.SetBasicAuth(config.BasicAuthUser, config.BasicAuthPass)
+	}
+	for k, v := range config.Headers {
+		req.Header.Set(k, v)
+	}
+}
@@ -0,0 +1,39 @@
+package scrapper
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestApplyRequestConfig(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://example.com/form.pdf", nil)
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+
+	config := &Config{
+		UserAgent:     "test-agent/1.0",
+		BasicAuthUser: "alice",
+		BasicAuthPass: "secret",
+		Headers:       map[string]string{"X-Test": "1"},
+	}
+	applyRequestConfig(req, config)
+
+	if got := req.Header.Get("User-Agent"); got != "test-agent/1.0" {
+		t.Errorf("expected User-Agent to be set, got %q", got)
+	}
+	if got := req.Header.Get("X-Test"); got != "1" {
+		t.Errorf("expected X-Test header to be set, got %q", got)
+	}
+	user, pass, ok := req.BasicAuth()
+	if !ok || user != "alice" || pass != "secret" {
+		t.Errorf("expected basic auth alice/secret, got %q/%q ok=%v", user, pass, ok)
+	}
+}
+
+func TestHTTPClientInvalidProxy(t *testing.T) {
+	_, err := httpClient(&Config{HTTPProxy: "://not-a-url"})
+	if err == nil {
+		t.Fatal("expected an error for an invalid proxy URL")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/form"
 )
 
 // PDFFormExtractor implements FormExtractor for PDF files
@@ -16,9 +17,19 @@ type PDFFormExtractor struct {
 	file *os.File
 }
 
-// NewPDFFormExtractor creates a new PDF form extractor
-func NewPDFFormExtractor(url string) (*PDFFormExtractor, error) {
-	tempFile, err := downloadFile(url)
+// NewPDFFormExtractor creates a new PDF form extractor, downloading rawURL
+// through a client configured from config (proxy, cookie jar, user agent,
+// basic auth, extra headers), retrying up to config.MaxAttempts times.
+func NewPDFFormExtractor(rawURL string, config *Config) (*PDFFormExtractor, error) {
+	var tempFile *os.File
+	err := withRetry(config.MaxAttempts, func() error {
+		f, err := downloadFile(rawURL, config)
+		if err != nil {
+			return err
+		}
+		tempFile = f
+		return nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to download PDF: %w", err)
 	}
@@ -35,25 +46,78 @@ func (p *PDFFormExtractor) Extract() ([]FormField, error) {
 		return nil, fmt.Errorf("error listing form fields: %w", err)
 	}
 
-	var formFields []FormField
+	formFields := make([]FormField, 0, len(fields))
 	for _, field := range fields {
-		parts := strings.Fields(fmt.Sprintf("%v", field))
-		if len(parts) >= 3 {
-			fieldType := parts[2]
-			fieldName := strings.Join(parts[3:], " ")
-
-			cleanName, label := cleanPDFFieldName(fieldName)
-			formFields = append(formFields, FormField{
-				Name:  cleanName,
-				Type:  fieldType,
-				Label: label,
-			})
-		}
+		formFields = append(formFields, FormField{
+			Name:     field.Name,
+			Type:     pdfFieldType(field.Typ),
+			Label:    field.Name,
+			Value:    field.V,
+			Default:  field.Dv,
+			Options:  pdfFieldOptions(field),
+			Multiple: field.Typ == form.FTListBox,
+			Group:    pdfFieldGroup(field.Pages),
+		})
 	}
 
 	return formFields, nil
 }
 
+// pdfFieldType maps pdfcpu's form.FieldType to the same style of type string
+// HTMLFormExtractor produces, so formatters can treat both uniformly.
+func pdfFieldType(typ form.FieldType) string {
+	switch typ {
+	case form.FTDate:
+		return "date"
+	case form.FTCheckBox:
+		return "checkbox"
+	case form.FTComboBox:
+		return "select"
+	case form.FTListBox:
+		return "select-multiple"
+	case form.FTRadioButtonGroup:
+		return "radio"
+	default:
+		return "text"
+	}
+}
+
+// pdfFieldOptions splits a combo/list/radio field's comma-joined Opts into
+// FieldOptions, marking as selected whichever are present in the
+// comma-joined current value V.
+func pdfFieldOptions(field form.Field) []FieldOption {
+	if field.Opts == "" {
+		return nil
+	}
+
+	selected := make(map[string]bool)
+	for _, v := range strings.Split(field.V, ",") {
+		if v != "" {
+			selected[v] = true
+		}
+	}
+
+	values := strings.Split(field.Opts, ",")
+	options := make([]FieldOption, len(values))
+	for i, v := range values {
+		options[i] = FieldOption{Value: v, Selected: selected[v]}
+	}
+	return options
+}
+
+// pdfFieldGroup renders a field's page numbers as its Group, e.g. "1" or
+// "1,2" for a field that spans several pages.
+func pdfFieldGroup(pages []int) string {
+	if len(pages) == 0 {
+		return ""
+	}
+	ss := make([]string, len(pages))
+	for i, p := range pages {
+		ss[i] = strconv.Itoa(p)
+	}
+	return strings.Join(ss, ",")
+}
+
 // Close implements FormExtractor for PDFFormExtractor
 func (p *PDFFormExtractor) Close() error {
 	if p.file != nil {
@@ -68,13 +132,28 @@ func (p *PDFFormExtractor) Close() error {
 }
 
 // Helper function to download a file
-func downloadFile(url string) (*os.File, error) {
+func downloadFile(rawURL string, config *Config) (*os.File, error) {
 	tempFile, err := os.CreateTemp("", "form-*.pdf")
 	if err != nil {
 		return nil, fmt.Errorf("error creating temp file: %w", err)
 	}
 
-	resp, err := http.Get(url)
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		tempFile.Close()
+		os.Remove(tempFile.Name())
+		return nil, fmt.Errorf("error building request: %w", err)
+	}
+	applyRequestConfig(req, config)
+
+	client, err := httpClient(config)
+	if err != nil {
+		tempFile.Close()
+		os.Remove(tempFile.Name())
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
 	if err != nil {
 		tempFile.Close()
 		os.Remove(tempFile.Name())
@@ -103,40 +182,3 @@ func downloadFile(url string) (*os.File, error) {
 
 	return tempFile, nil
 }
-
-// Helper function to clean PDF field names
-func cleanPDFFieldName(name string) (string, string) {
-	parts := strings.Fields(name)
-	if len(parts) < 2 {
-		return name, name
-	}
-
-	baseName := strings.Join(parts[1:], " ")
-	baseName = strings.TrimSpace(baseName)
-
-	suffix := ""
-	if idx := strings.LastIndex(baseName, "_"); idx != -1 {
-		possibleSuffix := baseName[idx+1:]
-		if _, err := strconv.Atoi(possibleSuffix); err == nil {
-			suffix = baseName[idx:]
-			baseName = strings.TrimSpace(baseName[:idx])
-		}
-	}
-
-	baseName = strings.TrimSpace(baseName)
-	baseName = strings.Trim(baseName, "\"")
-	baseName = strings.Trim(baseName, "}")
-
-	cleanName := baseName
-	if suffix != "" {
-		cleanName = baseName + suffix
-	}
-
-	label := baseName
-	if suffix != "" {
-		suffixNum := strings.TrimPrefix(suffix, "_")
-		label = fmt.Sprintf("%s (%s)", baseName, suffixNum)
-	}
-
-	return cleanName, label
-}
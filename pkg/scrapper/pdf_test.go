@@ -0,0 +1,95 @@
+package scrapper
+
+import (
+	"os"
+	"testing"
+)
+
+// openTestPDF opens testdata/pdf/english.pdf, a pdfcpu sample AcroForm with
+// a radio button group, a combo box, a multi-select list box and several
+// checkboxes across two pages. The caller is responsible for closing it.
+func openTestPDF(t *testing.T) *os.File {
+	t.Helper()
+	f, err := os.Open("testdata/pdf/english.pdf")
+	if err != nil {
+		t.Fatalf("failed to open test PDF: %v", err)
+	}
+	t.Cleanup(func() { f.Close() })
+	return f
+}
+
+func fieldByName(t *testing.T, fields []FormField, name string) FormField {
+	t.Helper()
+	for _, f := range fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	t.Fatalf("no field named %q in %+v", name, fields)
+	return FormField{}
+}
+
+func TestPDFFormExtractorExtractOptionsAndDefaults(t *testing.T) {
+	p := &PDFFormExtractor{file: openTestPDF(t)}
+
+	fields, err := p.Extract()
+	if err != nil {
+		t.Fatalf("Extract returned error: %v", err)
+	}
+
+	combo := fieldByName(t, fields, "city22")
+	if combo.Type != "select" {
+		t.Errorf("expected city22 to be type select, got %q", combo.Type)
+	}
+	if combo.Value != "San Francisco" {
+		t.Errorf("expected city22 value San Francisco, got %q", combo.Value)
+	}
+	wantOpts := []string{"London", "San Francisco", "Sidney"}
+	if len(combo.Options) != len(wantOpts) {
+		t.Fatalf("expected %d combo options, got %+v", len(wantOpts), combo.Options)
+	}
+	for i, want := range wantOpts {
+		if combo.Options[i].Value != want {
+			t.Errorf("combo option %d = %q, want %q", i, combo.Options[i].Value, want)
+		}
+	}
+
+	listBox := fieldByName(t, fields, "city21")
+	if listBox.Type != "select-multiple" {
+		t.Errorf("expected city21 to be type select-multiple, got %q", listBox.Type)
+	}
+	if !listBox.Multiple {
+		t.Errorf("expected city21 to be marked Multiple")
+	}
+	if listBox.Value != "San Francisco" {
+		t.Errorf("expected city21 value San Francisco, got %q", listBox.Value)
+	}
+
+	radio := fieldByName(t, fields, "gender2")
+	if radio.Type != "radio" {
+		t.Errorf("expected gender2 to be type radio, got %q", radio.Type)
+	}
+	if radio.Value != "non-binary" {
+		t.Errorf("expected gender2 value non-binary, got %q", radio.Value)
+	}
+	var selected []string
+	for _, o := range radio.Options {
+		if o.Selected {
+			selected = append(selected, o.Value)
+		}
+	}
+	if len(selected) != 1 || selected[0] != "non-binary" {
+		t.Errorf("expected only non-binary selected, got %v", selected)
+	}
+	if radio.Group != "2" {
+		t.Errorf("expected gender2 group to be page 2, got %q", radio.Group)
+	}
+
+	checkbox := fieldByName(t, fields, "cb21")
+	if checkbox.Type != "checkbox" {
+		t.Errorf("expected cb21 to be type checkbox, got %q", checkbox.Type)
+	}
+	if checkbox.Value != "Yes" {
+		t.Errorf("expected cb21 value Yes, got %q", checkbox.Value)
+	}
+}
@@ -0,0 +1,119 @@
+package scrapper
+
+import (
+	"bufio"
+	"container/list"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"sync"
+)
+
+// defaultVisitQueueLRUSize bounds how many recently seen URL hashes
+// visitQueue keeps in memory. It trades perfect dedup (which would need a
+// hash per URL ever seen) for a small, fixed memory footprint across crawls
+// of thousands of pages, matching wecr's approach: a persisted log is the
+// source of truth, the LRU just short-circuits the common case of
+// re-queuing a page seen a moment ago.
+const defaultVisitQueueLRUSize = 10000
+
+// visitQueue deduplicates crawl URLs using a bounded in-memory LRU of seen
+// hashes backed by an append-only log file, so a crawl can resume (or at
+// least audit which pages it already visited) without keeping every URL in
+// RAM.
+type visitQueue struct {
+	mu      sync.Mutex
+	lru     *list.List
+	index   map[uint64]*list.Element
+	maxSize int
+	file    *os.File
+}
+
+// newVisitQueue opens (creating if necessary) the append-only log at path
+// and returns a visitQueue backed by it. If path is empty, the queue is
+// in-memory only for the lifetime of the process.
+func newVisitQueue(path string) (*visitQueue, error) {
+	q := &visitQueue{
+		lru:     list.New(),
+		index:   make(map[uint64]*list.Element),
+		maxSize: defaultVisitQueueLRUSize,
+	}
+
+	if path == "" {
+		return q, nil
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open visit queue file: %w", err)
+	}
+	q.file = file
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		q.remember(hashURL(scanner.Text()))
+	}
+	if err := scanner.Err(); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to read visit queue file: %w", err)
+	}
+
+	return q, nil
+}
+
+// Seen reports whether url has already been marked visited and is still
+// within the LRU window.
+func (q *visitQueue) Seen(url string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	_, ok := q.index[hashURL(url)]
+	return ok
+}
+
+// MarkVisited records url as visited: it is appended to the on-disk log (if
+// any) and remembered in the in-memory LRU.
+func (q *visitQueue) MarkVisited(url string) error {
+	q.mu.Lock()
+	q.remember(hashURL(url))
+	q.mu.Unlock()
+
+	if q.file == nil {
+		return nil
+	}
+	if _, err := q.file.WriteString(url + "\n"); err != nil {
+		return fmt.Errorf("failed to append to visit queue file: %w", err)
+	}
+	return nil
+}
+
+// remember inserts h as the most recently seen hash, evicting the oldest
+// entry once maxSize is exceeded. Callers must hold q.mu.
+func (q *visitQueue) remember(h uint64) {
+	if _, ok := q.index[h]; ok {
+		return
+	}
+	q.index[h] = q.lru.PushFront(h)
+	if q.lru.Len() > q.maxSize {
+		oldest := q.lru.Back()
+		q.lru.Remove(oldest)
+		delete(q.index, oldest.Value.(uint64))
+	}
+}
+
+// Close closes the underlying log file, if any.
+func (q *visitQueue) Close() error {
+	if q.file == nil {
+		return nil
+	}
+	if err := q.file.Close(); err != nil {
+		return fmt.Errorf("failed to close visit queue file: %w", err)
+	}
+	return nil
+}
+
+func hashURL(url string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(url))
+	return h.Sum64()
+}
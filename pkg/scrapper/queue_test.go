@@ -0,0 +1,54 @@
+package scrapper
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestVisitQueueDedup(t *testing.T) {
+	q, err := newVisitQueue("")
+	if err != nil {
+		t.Fatalf("newVisitQueue returned error: %v", err)
+	}
+	defer q.Close()
+
+	if q.Seen("https://example.com/a") {
+		t.Fatal("expected URL to be unseen before MarkVisited")
+	}
+
+	if err := q.MarkVisited("https://example.com/a"); err != nil {
+		t.Fatalf("MarkVisited returned error: %v", err)
+	}
+
+	if !q.Seen("https://example.com/a") {
+		t.Fatal("expected URL to be seen after MarkVisited")
+	}
+	if q.Seen("https://example.com/b") {
+		t.Fatal("expected a different URL to remain unseen")
+	}
+}
+
+func TestVisitQueuePersistsAcrossOpens(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "visited.log")
+
+	q1, err := newVisitQueue(path)
+	if err != nil {
+		t.Fatalf("newVisitQueue returned error: %v", err)
+	}
+	if err := q1.MarkVisited("https://example.com/a"); err != nil {
+		t.Fatalf("MarkVisited returned error: %v", err)
+	}
+	if err := q1.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	q2, err := newVisitQueue(path)
+	if err != nil {
+		t.Fatalf("re-opening visit queue returned error: %v", err)
+	}
+	defer q2.Close()
+
+	if !q2.Seen("https://example.com/a") {
+		t.Fatal("expected a re-opened visit queue to remember URLs from the log file")
+	}
+}
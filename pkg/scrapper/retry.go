@@ -0,0 +1,35 @@
+package scrapper
+
+import (
+	"fmt"
+	"time"
+)
+
+// retryBaseDelay is the delay before the second attempt of withRetry;
+// it doubles after each subsequent failure. It is a var, not a const, so
+// tests can zero it out instead of spending real wall-clock time sleeping.
+var retryBaseDelay = 500 * time.Millisecond
+
+// withRetry calls fn up to attempts times, backing off exponentially
+// between failures. attempts below 1 is treated as 1. It returns the last
+// error if every attempt fails.
+func withRetry(attempts int, fn func() error) error {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var lastErr error
+	delay := retryBaseDelay
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			time.Sleep(delay)
+			delay *= 2
+		}
+		if err := fn(); err != nil {
+			lastErr = err
+			continue
+		}
+		return nil
+	}
+	return fmt.Errorf("failed after %d attempts: %w", attempts, lastErr)
+}
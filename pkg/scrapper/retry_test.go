@@ -0,0 +1,51 @@
+package scrapper
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWithRetrySucceedsEventually(t *testing.T) {
+	restore := disableRetryBackoff()
+	defer restore()
+
+	attempts := 0
+	err := withRetry(3, func() error {
+		attempts++
+		if attempts < 2 {
+			return errors.New("not yet")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected eventual success, got error: %v", err)
+	}
+	if attempts != 2 {
+		t.Fatalf("expected 2 attempts, got %d", attempts)
+	}
+}
+
+func TestWithRetryExhausted(t *testing.T) {
+	restore := disableRetryBackoff()
+	defer restore()
+
+	attempts := 0
+	err := withRetry(3, func() error {
+		attempts++
+		return errors.New("boom")
+	})
+	if err == nil {
+		t.Fatal("expected an error once every attempt fails")
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+// disableRetryBackoff zeroes retryBaseDelay for the duration of a test so it
+// doesn't spend real wall-clock time sleeping, returning a func to restore it.
+func disableRetryBackoff() func() {
+	orig := retryBaseDelay
+	retryBaseDelay = 0
+	return func() { retryBaseDelay = orig }
+}
@@ -0,0 +1,110 @@
+package scrapper
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// robotsCache fetches and memoizes robots.txt disallow rules per host so
+// Crawl only fetches each site's robots.txt once.
+type robotsCache struct {
+	mu     sync.Mutex
+	rules  map[string][]string // host -> disallowed path prefixes for User-agent: *
+	client *http.Client
+}
+
+func newRobotsCache(client *http.Client) *robotsCache {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &robotsCache{rules: make(map[string][]string), client: client}
+}
+
+// Allowed reports whether rawURL may be fetched per its host's robots.txt.
+// A robots.txt that can't be fetched (missing, network error, non-200) is
+// treated as allow-all, matching the common crawler convention.
+func (c *robotsCache) Allowed(rawURL string) (bool, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	disallowed, err := c.disallowedPaths(u)
+	if err != nil {
+		return false, err
+	}
+
+	for _, prefix := range disallowed {
+		if prefix != "" && strings.HasPrefix(u.Path, prefix) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (c *robotsCache) disallowedPaths(u *url.URL) ([]string, error) {
+	host := u.Scheme + "://" + u.Host
+
+	c.mu.Lock()
+	if paths, ok := c.rules[host]; ok {
+		c.mu.Unlock()
+		return paths, nil
+	}
+	c.mu.Unlock()
+
+	paths := fetchRobotsDisallow(c.client, host)
+
+	c.mu.Lock()
+	c.rules[host] = paths
+	c.mu.Unlock()
+
+	return paths, nil
+}
+
+// fetchRobotsDisallow downloads host+"/robots.txt" and returns the
+// Disallow paths listed under "User-agent: *". Any failure is treated as
+// "no rules" rather than an error, since a missing robots.txt means
+// everything is allowed.
+func fetchRobotsDisallow(client *http.Client, host string) []string {
+	resp, err := client.Get(host + "/robots.txt")
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil
+	}
+
+	var paths []string
+	applies := false
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		field, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		field = strings.ToLower(strings.TrimSpace(field))
+		value = strings.TrimSpace(value)
+
+		switch field {
+		case "user-agent":
+			applies = value == "*"
+		case "disallow":
+			if applies && value != "" {
+				paths = append(paths, value)
+			}
+		}
+	}
+
+	return paths
+}
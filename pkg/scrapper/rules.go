@@ -0,0 +1,168 @@
+package scrapper
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// FieldRule describes how to locate and decode a single field within a page
+// that a Rule applies to.
+type FieldRule struct {
+	// Selector is a CSS selector used to find the field element(s).
+	// Either Selector or XPath must be set.
+	Selector string `json:"selector,omitempty" yaml:"selector,omitempty"`
+	// XPath is an XPath expression used to find the field element(s).
+	XPath string `json:"xpath,omitempty" yaml:"xpath,omitempty"`
+
+	// NameSelector, LabelSelector, TypeSelector and RequiredSelector are
+	// optional CSS selectors, relative to the matched element, used to
+	// resolve each attribute from a different node. When empty, the
+	// corresponding value falls back to the matched element's own
+	// attributes (name, type, aria-label/placeholder, required).
+	NameSelector     string `json:"nameSelector,omitempty" yaml:"nameSelector,omitempty"`
+	LabelSelector    string `json:"labelSelector,omitempty" yaml:"labelSelector,omitempty"`
+	TypeSelector     string `json:"typeSelector,omitempty" yaml:"typeSelector,omitempty"`
+	RequiredSelector string `json:"requiredSelector,omitempty" yaml:"requiredSelector,omitempty"`
+
+	// Type, when set, overrides whatever type would otherwise be resolved.
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+
+	// JSExpr is an optional JS expression evaluated via rod's Page.Eval
+	// against the matched element (available as `elem`) to post-process
+	// the extracted value, e.g. "elem.value.trim().toUpperCase()".
+	JSExpr string `json:"jsExpr,omitempty" yaml:"jsExpr,omitempty"`
+}
+
+// Rule is a per-domain set of FieldRules that apply to pages whose URL
+// matches URLPattern.
+type Rule struct {
+	// Name identifies the rule, e.g. "google-forms".
+	Name string `json:"name" yaml:"name"`
+	// URLPattern is a regular expression matched against the page URL
+	// (including host) to decide whether this rule applies.
+	URLPattern string `json:"urlPattern" yaml:"urlPattern"`
+	// Fields lists the field rules this Rule contributes.
+	Fields []FieldRule `json:"fields" yaml:"fields"`
+
+	urlRegexp *regexp.Regexp
+}
+
+// Matches reports whether the rule applies to the given page URL.
+func (r *Rule) Matches(url string) bool {
+	if r.urlRegexp == nil {
+		return false
+	}
+	return r.urlRegexp.MatchString(url)
+}
+
+// LoadRules reads every *.json, *.yaml and *.yml file in dir and parses it
+// into one or more Rules. A file may contain a single Rule object or a JSON/
+// YAML array of Rules. Files that fail to parse are reported as an error
+// that names the offending path; LoadRules does not partially apply a
+// directory.
+func LoadRules(dir string) ([]Rule, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules directory: %w", err)
+	}
+
+	var rules []Rule
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if ext != ".json" && ext != ".yaml" && ext != ".yml" {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read rule file %s: %w", path, err)
+		}
+
+		parsed, err := parseRuleFile(data, ext)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse rule file %s: %w", path, err)
+		}
+
+		for i := range parsed {
+			if err := compileRule(&parsed[i]); err != nil {
+				return nil, fmt.Errorf("invalid rule %q in %s: %w", parsed[i].Name, path, err)
+			}
+		}
+
+		rules = append(rules, parsed...)
+	}
+
+	return rules, nil
+}
+
+func parseRuleFile(data []byte, ext string) ([]Rule, error) {
+	unmarshal := json.Unmarshal
+	if ext == ".yaml" || ext == ".yml" {
+		unmarshal = yaml.Unmarshal
+	}
+
+	// Try a list of rules first, then fall back to a single rule.
+	var rules []Rule
+	if err := unmarshal(data, &rules); err == nil && len(rules) > 0 {
+		return rules, nil
+	}
+
+	var rule Rule
+	if err := unmarshal(data, &rule); err != nil {
+		return nil, err
+	}
+	return []Rule{rule}, nil
+}
+
+func compileRule(r *Rule) error {
+	if r.URLPattern == "" {
+		return fmt.Errorf("missing urlPattern")
+	}
+	re, err := regexp.Compile(r.URLPattern)
+	if err != nil {
+		return fmt.Errorf("invalid urlPattern: %w", err)
+	}
+	r.urlRegexp = re
+	return nil
+}
+
+// matchingRules returns the subset of rules whose URLPattern matches url.
+func matchingRules(rules []Rule, url string) []Rule {
+	var matched []Rule
+	for _, r := range rules {
+		if r.Matches(url) {
+			matched = append(matched, r)
+		}
+	}
+	return matched
+}
+
+// mergeFields merges extra fields into base, de-duplicated by Name. Fields
+// already present in base win over same-named fields in extra.
+func mergeFields(base, extra []FormField) []FormField {
+	seen := make(map[string]bool, len(base))
+	for _, f := range base {
+		seen[f.Name] = true
+	}
+
+	merged := base
+	for _, f := range extra {
+		if seen[f.Name] {
+			continue
+		}
+		seen[f.Name] = true
+		merged = append(merged, f)
+	}
+	return merged
+}
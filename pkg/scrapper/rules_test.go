@@ -0,0 +1,71 @@
+package scrapper
+
+import "testing"
+
+func TestLoadRules(t *testing.T) {
+	rules, err := LoadRules("testdata/rules")
+	if err != nil {
+		t.Fatalf("LoadRules returned error: %v", err)
+	}
+
+	if len(rules) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(rules))
+	}
+
+	names := map[string]bool{}
+	for _, r := range rules {
+		names[r.Name] = true
+	}
+	for _, want := range []string{"google-forms", "typeform"} {
+		if !names[want] {
+			t.Errorf("expected rule %q to be loaded", want)
+		}
+	}
+}
+
+func TestLoadRulesInvalidURLPattern(t *testing.T) {
+	if _, err := LoadRules("testdata/rules-invalid"); err == nil {
+		t.Fatal("expected LoadRules to fail on an invalid urlPattern")
+	}
+}
+
+func TestLoadRulesMissingDir(t *testing.T) {
+	if _, err := LoadRules("testdata/does-not-exist"); err == nil {
+		t.Fatal("expected LoadRules to fail on a missing directory")
+	}
+}
+
+func TestRuleMatches(t *testing.T) {
+	rules, err := LoadRules("testdata/rules")
+	if err != nil {
+		t.Fatalf("LoadRules returned error: %v", err)
+	}
+
+	matched := matchingRules(rules, "https://docs.google.com/forms/d/e/abc/viewform")
+	if len(matched) != 1 || matched[0].Name != "google-forms" {
+		t.Fatalf("expected only google-forms to match, got %+v", matched)
+	}
+
+	if matched := matchingRules(rules, "https://example.com/contact"); len(matched) != 0 {
+		t.Fatalf("expected no rules to match an unrelated URL, got %+v", matched)
+	}
+}
+
+func TestMergeFields(t *testing.T) {
+	base := []FormField{{Name: "email", Type: "email"}}
+	extra := []FormField{
+		{Name: "email", Type: "text"}, // should lose to base
+		{Name: "phone", Type: "tel"},  // should be added
+	}
+
+	merged := mergeFields(base, extra)
+	if len(merged) != 2 {
+		t.Fatalf("expected 2 merged fields, got %d: %+v", len(merged), merged)
+	}
+	if merged[0].Type != "email" {
+		t.Errorf("expected base field to win for duplicate name, got type %q", merged[0].Type)
+	}
+	if merged[1].Name != "phone" {
+		t.Errorf("expected extra field 'phone' to be appended, got %+v", merged[1])
+	}
+}
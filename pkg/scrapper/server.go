@@ -0,0 +1,368 @@
+package scrapper
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-rod/rod"
+)
+
+// Server exposes a Scrapper over HTTP: POST /extract, POST /fill, a minimal
+// operator dashboard at GET /, plus GET /healthz and GET /metrics. It keeps
+// one rod.Browser warm for the lifetime of the server and shares it across
+// requests (a new Page per request, via the same extractOnBrowser helper
+// ExtractBatch and Crawl use), since launching Chromium per request is by
+// far the dominant cost of an HTML extraction.
+type Server struct {
+	scrapper *Scrapper
+	browser  *rod.Browser
+	metrics  *serverMetrics
+}
+
+// NewServer creates a Server backed by s, launching the warm browser used
+// for every HTML extraction the server handles. Call Close to shut it down.
+func NewServer(s *Scrapper) (*Server, error) {
+	browser, err := newBrowser(s.config)
+	if err != nil {
+		return nil, err
+	}
+	return &Server{
+		scrapper: s,
+		browser:  browser,
+		metrics:  newServerMetrics(),
+	}, nil
+}
+
+// Close shuts down the server's warm browser.
+func (srv *Server) Close() error {
+	srv.browser.MustClose()
+	return nil
+}
+
+// Handler returns the server's http.Handler, so callers can mount it on
+// their own http.Server or middleware stack instead of using ListenAndServe.
+func (srv *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", srv.handleDashboard)
+	mux.HandleFunc("/extract", srv.handleExtract)
+	mux.HandleFunc("/fill", srv.handleFill)
+	mux.HandleFunc("/healthz", srv.handleHealthz)
+	mux.HandleFunc("/metrics", srv.handleMetrics)
+	return mux
+}
+
+// ListenAndServe starts serving srv.Handler() on addr. It blocks until the
+// server stops, same as http.ListenAndServe.
+func (srv *Server) ListenAndServe(addr string) error {
+	return http.ListenAndServe(addr, srv.Handler())
+}
+
+type extractRequest struct {
+	URL    string `json:"url"`
+	Format string `json:"format,omitempty"`
+}
+
+// handleExtract implements POST /extract {"url", "format"}, reusing the
+// server's warm browser for HTML pages and rendering the result with the
+// Formatter named by format (default "json").
+func (srv *Server) handleExtract(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req extractRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+	if req.URL == "" {
+		http.Error(w, "url is required", http.StatusBadRequest)
+		return
+	}
+
+	format := req.Format
+	if format == "" {
+		format = "json"
+	}
+	formatter, ok := Formatters[format]
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown format %q", format), http.StatusBadRequest)
+		return
+	}
+
+	kind := extractorKind(req.URL)
+	start := time.Now()
+	fields, err := srv.scrapper.extractOnBrowser(srv.browser, req.URL)
+	srv.metrics.record(kind, time.Since(start), err)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to extract fields: %v", err), http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentTypeFor(format))
+	if err := formatter.Format(fields, w); err != nil {
+		http.Error(w, fmt.Sprintf("failed to format fields: %v", err), http.StatusInternalServerError)
+	}
+}
+
+// handleFill implements POST /fill: a multipart/form-data request with a
+// "pdf" file part and a "fields" part holding the JSON fields (with value
+// populated, as emitted by /extract) to fill in. It writes the filled PDF
+// bytes as the response body.
+func (srv *Server) handleFill(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		http.Error(w, fmt.Sprintf("invalid multipart form: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	pdfFile, _, err := r.FormFile("pdf")
+	if err != nil {
+		http.Error(w, "missing \"pdf\" file part", http.StatusBadRequest)
+		return
+	}
+	defer pdfFile.Close()
+
+	var fields []FormField
+	if err := json.Unmarshal([]byte(r.FormValue("fields")), &fields); err != nil {
+		http.Error(w, fmt.Sprintf("invalid \"fields\" JSON: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	values := make(map[string]string, len(fields))
+	for _, f := range fields {
+		values[f.Name] = f.Value
+	}
+
+	start := time.Now()
+	w.Header().Set("Content-Type", "application/pdf")
+	err = FillPDFForm(pdfFile, w, values)
+	srv.metrics.record("fill", time.Since(start), err)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to fill form: %v", err), http.StatusInternalServerError)
+	}
+}
+
+// handleHealthz implements GET /healthz for liveness/readiness probes.
+func (srv *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintln(w, `{"status":"ok"}`)
+}
+
+// handleMetrics implements GET /metrics in Prometheus text exposition
+// format: request/failure counters and latency sum/count, each broken down
+// by extractor kind ("html", "pdf" or "fill").
+func (srv *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	srv.metrics.writeTo(w)
+}
+
+// extractorKind classifies a URL the same way newFormExtractor routes it, so
+// metrics can be broken down by extractor type.
+func extractorKind(rawURL string) string {
+	if strings.HasSuffix(strings.ToLower(rawURL), ".pdf") {
+		return "pdf"
+	}
+	return "html"
+}
+
+// contentTypeFor maps a Formatters key to the Content-Type its output
+// should be served with.
+func contentTypeFor(format string) string {
+	switch format {
+	case "csv":
+		return "text/csv; charset=utf-8"
+	case "yaml":
+		return "application/yaml; charset=utf-8"
+	case "html":
+		return "text/html; charset=utf-8"
+	case "go-struct":
+		return "text/plain; charset=utf-8"
+	default:
+		return "application/json; charset=utf-8"
+	}
+}
+
+// serverMetrics accumulates extraction counters and latencies per extractor
+// kind for handleMetrics to expose.
+type serverMetrics struct {
+	mu           sync.Mutex
+	requests     map[string]int64
+	failures     map[string]int64
+	latencySum   map[string]float64
+	latencyCount map[string]int64
+}
+
+func newServerMetrics() *serverMetrics {
+	return &serverMetrics{
+		requests:     make(map[string]int64),
+		failures:     make(map[string]int64),
+		latencySum:   make(map[string]float64),
+		latencyCount: make(map[string]int64),
+	}
+}
+
+func (m *serverMetrics) record(kind string, latency time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[kind]++
+	if err != nil {
+		m.failures[kind]++
+	}
+	m.latencySum[kind] += latency.Seconds()
+	m.latencyCount[kind]++
+}
+
+func (m *serverMetrics) writeTo(w http.ResponseWriter) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	fmt.Fprintln(w, "# HELP form_field_extractor_requests_total Total number of extract/fill requests, by kind.")
+	fmt.Fprintln(w, "# TYPE form_field_extractor_requests_total counter")
+	for kind, n := range m.requests {
+		fmt.Fprintf(w, "form_field_extractor_requests_total{kind=%q} %d\n", kind, n)
+	}
+
+	fmt.Fprintln(w, "# HELP form_field_extractor_failures_total Total number of extract/fill requests that failed, by kind.")
+	fmt.Fprintln(w, "# TYPE form_field_extractor_failures_total counter")
+	for kind, n := range m.failures {
+		fmt.Fprintf(w, "form_field_extractor_failures_total{kind=%q} %d\n", kind, n)
+	}
+
+	fmt.Fprintln(w, "# HELP form_field_extractor_latency_seconds_sum Sum of request latencies in seconds, by kind.")
+	fmt.Fprintln(w, "# TYPE form_field_extractor_latency_seconds_sum counter")
+	for kind, sum := range m.latencySum {
+		fmt.Fprintf(w, "form_field_extractor_latency_seconds_sum{kind=%q} %f\n", kind, sum)
+	}
+
+	fmt.Fprintln(w, "# HELP form_field_extractor_latency_seconds_count Count of request latency observations, by kind.")
+	fmt.Fprintln(w, "# TYPE form_field_extractor_latency_seconds_count counter")
+	for kind, n := range m.latencyCount {
+		fmt.Fprintf(w, "form_field_extractor_latency_seconds_count{kind=%q} %d\n", kind, n)
+	}
+}
+
+// handleDashboard implements GET / with a minimal operator dashboard: paste
+// a URL, pick an output format, see the extracted fields in a table, and
+// download the result in any registered format.
+func (srv *Server) handleDashboard(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, dashboardHTML(formatNames()))
+}
+
+// formatNames returns the sorted set of registered Formatters keys, for the
+// dashboard's format <select>.
+func formatNames() []string {
+	names := make([]string, 0, len(Formatters))
+	for name := range Formatters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// dashboardHTML renders the operator dashboard page. It's a self-contained
+// page (inline CSS/JS, no build step) that calls POST /extract for both the
+// on-page table and the download link.
+func dashboardHTML(formats []string) string {
+	var options strings.Builder
+	for _, f := range formats {
+		fmt.Fprintf(&options, "<option value=%q>%s</option>", f, f)
+	}
+
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>form-field-extractor</title>
+<style>
+  body { font-family: sans-serif; max-width: 60rem; margin: 2rem auto; }
+  input[type=text] { width: 28rem; }
+  table { border-collapse: collapse; width: 100%%; margin-top: 1rem; }
+  th, td { border: 1px solid #ccc; padding: 0.3rem 0.6rem; text-align: left; }
+  #error { color: #b00020; }
+</style>
+</head>
+<body>
+<h1>form-field-extractor</h1>
+<p>
+  <input type="text" id="url" placeholder="https://example.com/form">
+  <select id="format">%s</select>
+  <button onclick="extract()">Extract</button>
+  <button onclick="download_()">Download</button>
+</p>
+<p id="error"></p>
+<table id="fields"></table>
+<script>
+async function extract() {
+  const url = document.getElementById('url').value;
+  const err = document.getElementById('error');
+  const table = document.getElementById('fields');
+  err.textContent = '';
+  table.innerHTML = '';
+  const resp = await fetch('/extract', {
+    method: 'POST',
+    headers: {'Content-Type': 'application/json'},
+    body: JSON.stringify({url: url, format: 'json'}),
+  });
+  if (!resp.ok) {
+    err.textContent = await resp.text();
+    return;
+  }
+  const fields = await resp.json();
+  table.innerHTML = '';
+  const header = table.insertRow();
+  for (const h of ['name', 'type', 'label', 'required', 'value', 'default', 'options']) {
+    const th = document.createElement('th');
+    th.textContent = h;
+    header.appendChild(th);
+  }
+  for (const f of (fields || [])) {
+    const opts = (f.options || []).map(o => o.value).join(', ');
+    const row = table.insertRow();
+    for (const cell of [f.name||'', f.type||'', f.label||'', String(f.required||false), f.value||'', f.default||'', opts]) {
+      row.insertCell().textContent = cell;
+    }
+  }
+}
+
+async function download_() {
+  const url = document.getElementById('url').value;
+  const format = document.getElementById('format').value;
+  const err = document.getElementById('error');
+  err.textContent = '';
+  const resp = await fetch('/extract', {
+    method: 'POST',
+    headers: {'Content-Type': 'application/json'},
+    body: JSON.stringify({url: url, format: format}),
+  });
+  if (!resp.ok) {
+    err.textContent = await resp.text();
+    return;
+  }
+  const blob = await resp.blob();
+  const a = document.createElement('a');
+  a.href = URL.createObjectURL(blob);
+  a.download = 'fields.' + format;
+  a.click();
+}
+</script>
+</body>
+</html>
+`, options.String())
+}
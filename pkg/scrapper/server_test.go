@@ -0,0 +1,210 @@
+package scrapper
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+// newTestServer builds a Server without launching a real browser, since
+// these tests only exercise PDF and non-browser endpoints.
+func newTestServer() *Server {
+	return &Server{
+		scrapper: New(),
+		metrics:  newServerMetrics(),
+	}
+}
+
+func TestServerHandleHealthz(t *testing.T) {
+	srv := newTestServer()
+
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	rec := httptest.NewRecorder()
+	srv.handleHealthz(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), `"status":"ok"`) {
+		t.Errorf("unexpected body: %s", rec.Body.String())
+	}
+}
+
+func TestServerHandleDashboard(t *testing.T) {
+	srv := newTestServer()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	srv.handleDashboard(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+
+	out := rec.Body.String()
+	if strings.Contains(out, "table.innerHTML = html") {
+		t.Error("dashboard still builds the fields table via raw innerHTML string concatenation, which is XSS-prone against attacker-controlled field data")
+	}
+	if !strings.Contains(out, "row.insertCell().textContent") {
+		t.Error("expected the fields table to be built with textContent so field data can't inject markup")
+	}
+}
+
+func TestServerHandleExtractPDF(t *testing.T) {
+	pdfData, err := os.ReadFile("testdata/pdf/english.pdf")
+	if err != nil {
+		t.Fatalf("failed to read test PDF: %v", err)
+	}
+
+	pdfServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(pdfData)
+	}))
+	defer pdfServer.Close()
+
+	srv := newTestServer()
+
+	body, _ := json.Marshal(extractRequest{URL: pdfServer.URL + "/form.pdf", Format: "json"})
+	req := httptest.NewRequest(http.MethodPost, "/extract", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	srv.handleExtract(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var fields []FormField
+	if err := json.Unmarshal(rec.Body.Bytes(), &fields); err != nil {
+		t.Fatalf("response is not valid JSON: %v", err)
+	}
+	if len(fields) == 0 {
+		t.Fatal("expected at least one field")
+	}
+
+	if got := srv.metrics.requests["pdf"]; got != 1 {
+		t.Errorf("expected 1 pdf request recorded, got %d", got)
+	}
+}
+
+// TestServerHandleExtractHTMLEscapesFieldValues guards the network-reachable
+// path: POST /extract {"format":"html"} against a page whose form carries an
+// attacker-controlled value must not let that value break out of the
+// rendered <input>'s attributes, since an operator previewing an untrusted
+// page's form gets the result rendered in their own browser session.
+func TestServerHandleExtractHTMLEscapesFieldValues(t *testing.T) {
+	src, err := os.Open("testdata/pdf/english.pdf")
+	if err != nil {
+		t.Fatalf("failed to open test PDF: %v", err)
+	}
+	defer src.Close()
+
+	var filled bytes.Buffer
+	values := map[string]string{"firstName1": `" ><script>alert(1)</script><x y="`}
+	if err := FillPDFForm(src, &filled, values); err != nil {
+		t.Fatalf("failed to fill test PDF: %v", err)
+	}
+
+	pdfServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(filled.Bytes())
+	}))
+	defer pdfServer.Close()
+
+	srv := newTestServer()
+
+	body, _ := json.Marshal(extractRequest{URL: pdfServer.URL + "/form.pdf", Format: "html"})
+	req := httptest.NewRequest(http.MethodPost, "/extract", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	srv.handleExtract(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	out := rec.Body.String()
+	if strings.Contains(out, "<script>") {
+		t.Errorf("expected /extract?format=html to HTML-escape field values, got a live <script> tag: %s", out)
+	}
+}
+
+func TestServerHandleExtractRejectsUnknownFormat(t *testing.T) {
+	srv := newTestServer()
+
+	body, _ := json.Marshal(extractRequest{URL: "https://example.com/form.pdf", Format: "nonsense"})
+	req := httptest.NewRequest(http.MethodPost, "/extract", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	srv.handleExtract(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Code)
+	}
+}
+
+func TestServerHandleFill(t *testing.T) {
+	pdfData, err := os.ReadFile("testdata/pdf/english.pdf")
+	if err != nil {
+		t.Fatalf("failed to read test PDF: %v", err)
+	}
+
+	fields, _ := json.Marshal([]FormField{{Name: "firstName1", Value: "Ada"}})
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	pw, err := mw.CreateFormFile("pdf", "english.pdf")
+	if err != nil {
+		t.Fatalf("failed to create form file: %v", err)
+	}
+	if _, err := pw.Write(pdfData); err != nil {
+		t.Fatalf("failed to write pdf part: %v", err)
+	}
+	if err := mw.WriteField("fields", string(fields)); err != nil {
+		t.Fatalf("failed to write fields part: %v", err)
+	}
+	if err := mw.Close(); err != nil {
+		t.Fatalf("failed to close multipart writer: %v", err)
+	}
+
+	srv := newTestServer()
+
+	req := httptest.NewRequest(http.MethodPost, "/fill", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	rec := httptest.NewRecorder()
+	srv.handleFill(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec.Body.Len() == 0 {
+		t.Fatal("expected a non-empty filled PDF body")
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/pdf" {
+		t.Errorf("expected Content-Type application/pdf, got %q", got)
+	}
+}
+
+func TestServerHandleMetrics(t *testing.T) {
+	srv := newTestServer()
+	srv.metrics.record("pdf", 0, nil)
+	srv.metrics.record("html", 0, errFake)
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	rec := httptest.NewRecorder()
+	srv.handleMetrics(rec, req)
+
+	out := rec.Body.String()
+	if !strings.Contains(out, `form_field_extractor_requests_total{kind="pdf"} 1`) {
+		t.Errorf("missing pdf request counter, got: %s", out)
+	}
+	if !strings.Contains(out, `form_field_extractor_failures_total{kind="html"} 1`) {
+		t.Errorf("missing html failure counter, got: %s", out)
+	}
+}
+
+var errFake = errorString("fake failure")
+
+type errorString string
+
+func (e errorString) Error() string { return string(e) }